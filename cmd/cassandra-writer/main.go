@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
@@ -27,6 +28,7 @@ import (
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/mg-contrib/consumers/writers/cassandra"
 	cassandraclient "github.com/absmach/mg-contrib/pkg/clients/cassandra"
+	"github.com/absmach/mg-contrib/pkg/middleware"
 	"github.com/caarlos0/env/v10"
 	"github.com/gocql/gocql"
 	"golang.org/x/sync/errgroup"
@@ -47,6 +49,19 @@ type config struct {
 	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                 envDefault:"true"`
 	InstanceID    string  `env:"MG_CASSANDRA_WRITER_INSTANCE_ID"   envDefault:""`
 	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"             envDefault:"1.0"`
+
+	BatchingEnabled  bool          `env:"MG_CASSANDRA_WRITER_BATCH_ENABLED"     envDefault:"false"`
+	BatchSize        int           `env:"MG_CASSANDRA_WRITER_BATCH_SIZE"        envDefault:"500"`
+	BatchBytes       int           `env:"MG_CASSANDRA_WRITER_BATCH_BYTES"       envDefault:"1048576"`
+	BatchInterval    time.Duration `env:"MG_CASSANDRA_WRITER_BATCH_INTERVAL"    envDefault:"1s"`
+	BatchConcurrency int           `env:"MG_CASSANDRA_WRITER_BATCH_CONCURRENCY" envDefault:"1"`
+
+	CircuitEnabled     bool          `env:"MG_CASSANDRA_WRITER_CIRCUIT_ENABLED"       envDefault:"false"`
+	CircuitTimeout     time.Duration `env:"MG_CASSANDRA_WRITER_CIRCUIT_TIMEOUT"       envDefault:"30s"`
+	CircuitMaxFailures uint32        `env:"MG_CASSANDRA_WRITER_CIRCUIT_MAX_FAILURES"  envDefault:"5"`
+	RateLimitEnabled   bool          `env:"MG_CASSANDRA_WRITER_RATE_LIMIT_ENABLED"    envDefault:"false"`
+	RateLimitRPS       int           `env:"MG_CASSANDRA_WRITER_RATE_LIMIT_RPS"        envDefault:"1000"`
+	RateLimitBurst     int           `env:"MG_CASSANDRA_WRITER_RATE_LIMIT_BURST"      envDefault:"1000"`
 }
 
 func main() {
@@ -105,7 +120,7 @@ func main() {
 	tracer := tp.Tracer(svcName)
 
 	// Create new cassandra-writer repo
-	repo := newService(csdSession, logger)
+	repo := newService(ctx, csdSession, cfg, logger)
 	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
 
 	// Create new pub sub broker
@@ -146,8 +161,30 @@ func main() {
 	}
 }
 
-func newService(session *gocql.Session, logger *slog.Logger) consumers.BlockingConsumer {
-	repo := cassandra.New(session)
+func newService(ctx context.Context, session *gocql.Session, cfg config, logger *slog.Logger) consumers.BlockingConsumer {
+	var repo consumers.BlockingConsumer
+	if cfg.BatchingEnabled {
+		repo = cassandra.NewBatching(ctx, session, cassandra.BatchConfig{
+			MaxSize:       cfg.BatchSize,
+			MaxBytes:      cfg.BatchBytes,
+			FlushInterval: cfg.BatchInterval,
+			Concurrency:   cfg.BatchConcurrency,
+		}, logger)
+	} else {
+		repo = cassandra.New(session)
+	}
+
+	if cfg.CircuitEnabled {
+		repo = middleware.NewCircuitBreaker(repo, middleware.Settings{
+			Name:                svcName,
+			Timeout:             cfg.CircuitTimeout,
+			ConsecutiveFailures: cfg.CircuitMaxFailures,
+		})
+	}
+	if cfg.RateLimitEnabled {
+		repo = middleware.NewRateLimiter(repo, cfg.RateLimitRPS, cfg.RateLimitBurst)
+	}
+
 	repo = api.LoggingMiddleware(repo, logger)
 	counter, latency := prometheus.MakeMetrics("cassandra", "message_writer")
 	repo = api.MetricsMiddleware(repo, counter, latency)
@@ -11,6 +11,8 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"sync"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/magistrala"
@@ -18,20 +20,25 @@ import (
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/pkg/prometheus"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/absmach/mg-contrib/opcua"
 	"github.com/absmach/mg-contrib/opcua/api"
-	"github.com/absmach/mg-contrib/opcua/db"
+	"github.com/absmach/mg-contrib/opcua/bolt"
 	opcuaevents "github.com/absmach/mg-contrib/opcua/events"
 	"github.com/absmach/mg-contrib/opcua/gopcua"
+	opcuapg "github.com/absmach/mg-contrib/opcua/postgres"
 	redisclient "github.com/absmach/mg-contrib/pkg/clients/redis"
+	"github.com/absmach/mg-contrib/pkg/middleware"
 	"github.com/caarlos0/env/v10"
 	"github.com/go-redis/redis/v8"
+	bboltdb "go.etcd.io/bbolt"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -45,6 +52,8 @@ const (
 	connectionRMPrefix = "connection"
 
 	thingsStream = "events.magistrala.things"
+
+	writeConsumerID = "opcua-adapter-write"
 )
 
 type config struct {
@@ -57,8 +66,24 @@ type config struct {
 	ESURL          string  `env:"MG_ES_URL"                           envDefault:"nats://localhost:4222"`
 	RouteMapURL    string  `env:"MG_OPCUA_ADAPTER_ROUTE_MAP_URL"      envDefault:"redis://localhost:6379/0"`
 	TraceRatio     float64 `env:"MG_JAEGER_TRACE_RATIO"               envDefault:"1.0"`
+	WriteTopic     string  `env:"MG_OPCUA_ADAPTER_WRITE_TOPIC"        envDefault:"channels.>.messages.opcua.write"`
+
+	CircuitEnabled     bool          `env:"MG_OPCUA_ADAPTER_CIRCUIT_ENABLED"      envDefault:"false"`
+	CircuitTimeout     time.Duration `env:"MG_OPCUA_ADAPTER_CIRCUIT_TIMEOUT"      envDefault:"30s"`
+	CircuitMaxFailures uint32        `env:"MG_OPCUA_ADAPTER_CIRCUIT_MAX_FAILURES" envDefault:"5"`
+	RateLimitEnabled   bool          `env:"MG_OPCUA_ADAPTER_RATE_LIMIT_ENABLED"   envDefault:"false"`
+	RateLimitRPS       int           `env:"MG_OPCUA_ADAPTER_RATE_LIMIT_RPS"       envDefault:"100"`
+	RateLimitBurst     int           `env:"MG_OPCUA_ADAPTER_RATE_LIMIT_BURST"     envDefault:"100"`
+
+	DBType         string `env:"MG_OPCUA_ADAPTER_DB_TYPE"          envDefault:"bolt"`
+	BoltPath       string `env:"MG_OPCUA_ADAPTER_DB_BOLT_PATH"     envDefault:"/tmp/opcua.db"`
+	ResubWorkers   int    `env:"MG_OPCUA_ADAPTER_RESUB_WORKERS"    envDefault:"10"`
+	ResubPageLimit uint64 `env:"MG_OPCUA_ADAPTER_RESUB_PAGE_LIMIT" envDefault:"100"`
+	NodeSecretKey  string `env:"MG_OPCUA_ADAPTER_NODE_SECRET_KEY"  envDefault:""`
 }
 
+const envPrefixDB = "MG_OPCUA_ADAPTER_DB_"
+
 func main() {
 	ctx, httpCancel := context.WithCancel(context.Background())
 	g, ctx := errgroup.WithContext(ctx)
@@ -130,12 +155,20 @@ func main() {
 	defer pubSub.Close()
 	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
 
-	sub := gopcua.NewSubscriber(ctx, pubSub, thingRM, chanRM, connRM, logger)
+	nodes, err := newNodeRepository(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to setup %s node repository: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	sub := gopcua.NewSubscriber(ctx, pubSub, thingRM, chanRM, connRM, nodes, logger)
 	browser := gopcua.NewBrowser(ctx, logger)
+	writer := gopcua.NewWriter()
 
-	svc := newService(sub, browser, thingRM, chanRM, connRM, opcConfig, logger)
+	svc := newService(sub, browser, writer, thingRM, chanRM, connRM, nodes, opcConfig, cfg, logger)
 
-	go subscribeToStoredSubs(ctx, sub, opcConfig, logger)
+	go subscribeToStoredSubs(ctx, sub, nodes, opcConfig, cfg, logger)
 
 	if err = subscribeToThingsES(ctx, svc, cfg, logger); err != nil {
 		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
@@ -145,6 +178,16 @@ func main() {
 
 	logger.Info("Subscribed to Event Store")
 
+	if err := pubSub.Subscribe(ctx, messaging.SubscriberConfig{
+		ID:      writeConsumerID,
+		Topic:   cfg.WriteTopic,
+		Handler: opcuaevents.NewWriteConsumer(svc, logger),
+	}); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to write-back topic: %s", err))
+		exitCode = 1
+		return
+	}
+
 	hs := httpserver.NewServer(ctx, httpCancel, svcName, httpServerConfig, api.MakeHandler(svc, logger, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
@@ -165,22 +208,71 @@ func main() {
 	}
 }
 
-func subscribeToStoredSubs(ctx context.Context, sub opcua.Subscriber, cfg opcua.Config, logger *slog.Logger) {
-	// Get all stored subscriptions
-	nodes, err := db.ReadAll()
-	if err != nil {
-		logger.Warn(fmt.Sprintf("Read stored subscriptions failed: %s", err))
+// subscribeToStoredSubs re-establishes every previously persisted
+// subscription on adapter startup. sub.Subscribe blocks for the lifetime of
+// the subscription (it only returns once ctx is done), so each resubscribed
+// node gets its own goroutine rather than being handed to a fixed-size pool
+// of long-lived workers, which would permanently pin one worker per node for
+// the life of the process and stall resubscription of every node beyond the
+// pool size. ResubWorkers instead bounds how many subscriptions may be in
+// the process of being established concurrently, via a semaphore acquired
+// before a goroutine is spawned and released only once Subscribe returns.
+func subscribeToStoredSubs(ctx context.Context, sub opcua.Subscriber, nodes opcua.NodeRepository, baseCfg opcua.Config, cfg config, logger *slog.Logger) {
+	workers := cfg.ResubWorkers
+	if workers < 1 {
+		workers = 1
 	}
 
-	for _, n := range nodes {
-		cfg.ServerURI = n.ServerURI
-		cfg.NodeID = n.NodeID
-		go func() {
-			if err := sub.Subscribe(ctx, cfg); err != nil {
-				logger.Warn(fmt.Sprintf("Subscription failed: %s", err))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	resubscribe := func(n opcua.Node) {
+		defer wg.Done()
+		defer func() { <-sem }()
+
+		nCfg := baseCfg
+		nCfg.ServerURI = n.ServerURI
+		nCfg.NodeID = n.NodeID
+		nCfg.SecurityPolicy = n.SecurityPolicy
+		nCfg.SecurityMode = n.SecurityMode
+		nCfg.CertFile = n.CertFile
+		nCfg.KeyFile = n.KeyFile
+		nCfg.AuthMode = n.AuthMode
+		nCfg.Username = n.Username
+		nCfg.Password = n.Password
+		nCfg.AuthCertFile = n.AuthCertFile
+		nCfg.AuthKeyFile = n.AuthKeyFile
+		if err := sub.Subscribe(ctx, n.Connection, nCfg); err != nil {
+			logger.Warn(fmt.Sprintf("Subscription failed: %s", err))
+		}
+	}
+
+	pm := opcua.PageMetadata{Offset: 0, Limit: cfg.ResubPageLimit}
+	for {
+		page, err := nodes.RetrieveAll(ctx, pm)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Read stored subscriptions failed: %s", err))
+			break
+		}
+
+		for _, n := range page.Nodes {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+				return
 			}
-		}()
+			wg.Add(1)
+			go resubscribe(n)
+		}
+
+		if pm.Offset+uint64(len(page.Nodes)) >= page.Total || len(page.Nodes) == 0 {
+			break
+		}
+		pm.Offset += uint64(len(page.Nodes))
 	}
+
+	wg.Wait()
 }
 
 func subscribeToThingsES(ctx context.Context, svc opcua.Service, cfg config, logger *slog.Logger) error {
@@ -202,8 +294,41 @@ func newRouteMapRepositoy(client *redis.Client, prefix string, logger *slog.Logg
 	return opcuaevents.NewRouteMapRepository(client, prefix)
 }
 
-func newService(sub opcua.Subscriber, browser opcua.Browser, thingRM, chanRM, connRM opcua.RouteMapRepository, opcuaConfig opcua.Config, logger *slog.Logger) opcua.Service {
-	svc := opcua.New(sub, browser, thingRM, chanRM, connRM, opcuaConfig, logger)
+func newNodeRepository(cfg config) (opcua.NodeRepository, error) {
+	switch cfg.DBType {
+	case "postgres":
+		pgConfig := pgclient.Config{}
+		if err := env.ParseWithOptions(&pgConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+			return nil, err
+		}
+		db, err := pgclient.Setup(pgConfig, *opcuapg.Migration())
+		if err != nil {
+			return nil, err
+		}
+		return opcuapg.NewRepository(db, cfg.NodeSecretKey), nil
+	default:
+		db, err := bboltdb.Open(cfg.BoltPath, 0o600, nil)
+		if err != nil {
+			return nil, err
+		}
+		return bolt.NewRepository(db, cfg.NodeSecretKey)
+	}
+}
+
+func newService(sub opcua.Subscriber, browser opcua.Browser, writer opcua.Writer, thingRM, chanRM, connRM opcua.RouteMapRepository, nodes opcua.NodeRepository, opcuaConfig opcua.Config, cfg config, logger *slog.Logger) opcua.Service {
+	svc := opcua.New(sub, browser, writer, thingRM, chanRM, connRM, nodes, opcuaConfig, logger)
+
+	if cfg.CircuitEnabled {
+		svc = middleware.NewOPCUACircuitBreaker(svc, middleware.Settings{
+			Name:                svcName,
+			Timeout:             cfg.CircuitTimeout,
+			ConsecutiveFailures: cfg.CircuitMaxFailures,
+		})
+	}
+	if cfg.RateLimitEnabled {
+		svc = middleware.NewOPCUARateLimiter(svc, cfg.RateLimitRPS, cfg.RateLimitBurst)
+	}
+
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("opc_ua_adapter", "api")
 	svc = api.MetricsMiddleware(svc, counter, latency)
@@ -0,0 +1,257 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/gocql/gocql"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BatchConfig configures how the batching writer accumulates and flushes
+// inserts.
+type BatchConfig struct {
+	// MaxSize is the maximum number of rows buffered before a flush.
+	MaxSize int
+
+	// MaxBytes is the maximum approximate payload size buffered before a
+	// flush.
+	MaxBytes int
+
+	// FlushInterval is the maximum time a row can wait in the buffer before
+	// being flushed, regardless of size.
+	FlushInterval time.Duration
+
+	// Concurrency is the number of flush workers running concurrently.
+	Concurrency int
+
+	// Logged selects between gocql.LoggedBatch (atomic, slower) and
+	// gocql.UnloggedBatch (faster, per-table only) for the flush.
+	Logged bool
+}
+
+var (
+	batchesFlushed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cassandra",
+		Subsystem: "writer",
+		Name:      "batches_flushed_total",
+		Help:      "Number of Cassandra batches flushed, by trigger.",
+	}, []string{"trigger"})
+
+	batchRows = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "cassandra",
+		Subsystem: "writer",
+		Name:      "batch_rows_total",
+		Help:      "Number of rows written to Cassandra via batches.",
+	})
+
+	batchFlushLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "cassandra",
+		Subsystem: "writer",
+		Name:      "batch_flush_latency_seconds",
+		Help:      "Time taken to flush a batch of rows to Cassandra.",
+	})
+
+	batchRowFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "cassandra",
+		Subsystem: "writer",
+		Name:      "batch_row_failures_total",
+		Help:      "Number of rows that failed even after falling back to a per-row write.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(batchesFlushed, batchRows, batchFlushLatency, batchRowFailures)
+}
+
+var _ consumers.BlockingConsumer = (*batchingRepository)(nil)
+
+// job is one Consume call's statements, held in the buffer until a flush
+// reports back whether they actually made it to Cassandra.
+type job struct {
+	stmts []statement
+	done  chan error
+}
+
+type batchingRepository struct {
+	session *gocql.Session
+	cfg     BatchConfig
+	logger  *slog.Logger
+
+	mu       sync.Mutex
+	jobs     []*job
+	bufCount int
+	bufBytes int
+	flush    chan string
+}
+
+// NewBatching returns a Cassandra writer that accumulates inserts and
+// flushes them as a single batch once MaxSize rows, MaxBytes of payload, or
+// FlushInterval is reached, whichever comes first. Consume blocks until the
+// rows it submitted have actually been flushed (as part of a batch, or
+// individually if the batch failed), so callers see real write outcomes. A
+// row that fails as part of a batch is retried individually, so a single
+// poison message can't discard the rest of the batch; cfg.Concurrency flush
+// workers run so a slow flush doesn't stall the rest of the buffer.
+func NewBatching(ctx context.Context, session *gocql.Session, cfg BatchConfig, logger *slog.Logger) consumers.BlockingConsumer {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 500
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = 1 << 20
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+
+	br := &batchingRepository{
+		session: session,
+		cfg:     cfg,
+		logger:  logger,
+		flush:   make(chan string, cfg.Concurrency),
+	}
+
+	for i := 0; i < cfg.Concurrency; i++ {
+		go br.flushWorker(ctx)
+	}
+	go br.tick(ctx)
+
+	return br
+}
+
+func (br *batchingRepository) Consume(ctx context.Context, message interface{}) error {
+	stmts, err := statementsFor(message)
+	if err != nil {
+		return err
+	}
+
+	j := &job{stmts: stmts, done: make(chan error, 1)}
+
+	br.mu.Lock()
+	br.jobs = append(br.jobs, j)
+	br.bufCount += len(stmts)
+	for _, s := range stmts {
+		br.bufBytes += s.size
+	}
+	full := br.bufCount >= br.cfg.MaxSize || br.bufBytes >= br.cfg.MaxBytes
+	br.mu.Unlock()
+
+	if full {
+		br.triggerFlush("size")
+	}
+
+	select {
+	case err := <-j.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tick drives the interval-based flush trigger and the final flush on
+// shutdown; the size-triggered and interval-triggered flushes themselves
+// run on flushWorker so cfg.Concurrency of them can be in flight together.
+func (br *batchingRepository) tick(ctx context.Context) {
+	ticker := time.NewTicker(br.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			br.doFlush("shutdown")
+			return
+		case <-ticker.C:
+			br.triggerFlush("interval")
+		}
+	}
+}
+
+func (br *batchingRepository) flushWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case trigger := <-br.flush:
+			br.doFlush(trigger)
+		}
+	}
+}
+
+func (br *batchingRepository) triggerFlush(trigger string) {
+	select {
+	case br.flush <- trigger:
+	default:
+	}
+}
+
+func (br *batchingRepository) doFlush(trigger string) {
+	br.mu.Lock()
+	jobs := br.jobs
+	br.jobs = nil
+	br.bufCount = 0
+	br.bufBytes = 0
+	br.mu.Unlock()
+
+	if len(jobs) == 0 {
+		return
+	}
+
+	begin := time.Now()
+	defer func() {
+		batchFlushLatency.Observe(time.Since(begin).Seconds())
+	}()
+
+	batchType := gocql.UnloggedBatch
+	if br.cfg.Logged {
+		batchType = gocql.LoggedBatch
+	}
+
+	batch := br.session.NewBatch(batchType)
+	rows := 0
+	for _, j := range jobs {
+		for _, s := range j.stmts {
+			batch.Query(s.query, s.args...)
+			rows++
+		}
+	}
+
+	if err := br.session.ExecuteBatch(batch); err != nil {
+		// A single poison row shouldn't discard the whole batch: fall back
+		// to writing every job's rows individually, and report each job's
+		// own outcome rather than silently dropping it.
+		for _, j := range jobs {
+			j.done <- br.writeRows(j.stmts)
+		}
+	} else {
+		for _, j := range jobs {
+			j.done <- nil
+		}
+	}
+
+	batchesFlushed.With(prometheus.Labels{"trigger": trigger}).Inc()
+	batchRows.Add(float64(rows))
+}
+
+// writeRows writes stmts one at a time, used as the per-row fallback when a
+// batch fails. The first row that still fails is logged and counted, since
+// at that point there is no further fallback left for it.
+func (br *batchingRepository) writeRows(stmts []statement) error {
+	for _, s := range stmts {
+		if err := br.session.Query(s.query, s.args...).Exec(); err != nil {
+			batchRowFailures.Inc()
+			br.logger.Warn("cassandra row write failed after batch fallback: " + err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/mg-contrib/consumers/writers/cassandra"
+	casclient "github.com/absmach/mg-contrib/pkg/clients/cassandra"
+)
+
+func senmlMessages(n int) []senml.Message {
+	msgs := make([]senml.Message, n)
+	for i := range msgs {
+		msgs[i] = senml.Message{
+			Channel:   "channel",
+			Publisher: "publisher",
+			Protocol:  "mqtt",
+			Name:      "temperature",
+			Unit:      "C",
+			Value:     floatPtr(float64(i)),
+			Time:      float64(i),
+		}
+	}
+	return msgs
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func BenchmarkSingleRowWrite(b *testing.B) {
+	session, err := casclient.Connect(casclient.Config{Hosts: []string{addr}, Keyspace: keyspace})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer session.Close()
+
+	repo := cassandra.New(session)
+	msgs := senmlMessages(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := repo.Consume(context.Background(), msgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBatchingWrite(b *testing.B) {
+	session, err := casclient.Connect(casclient.Config{Hosts: []string{addr}, Keyspace: keyspace})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer session.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := cassandra.NewBatching(ctx, session, cassandra.BatchConfig{
+		MaxSize:       500,
+		FlushInterval: 50 * time.Millisecond,
+		Concurrency:   4,
+	}, logger)
+	msgs := senmlMessages(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := repo.Consume(context.Background(), msgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cassandra contains a Cassandra implementation of the consumers
+// writer, storing SenML and JSON messages for later retrieval.
+package cassandra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/gocql/gocql"
+)
+
+const (
+	senmlTable = "messages"
+	jsonTable  = "json"
+
+	insertSenml = `INSERT INTO messages (channel, subtopic, publisher, protocol, name, unit, value, string_value,
+		bool_value, data_value, sum, time, update_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	insertJSON = `INSERT INTO json (channel, created, subtopic, publisher, protocol, payload) VALUES (?, ?, ?, ?, ?, ?)`
+)
+
+// Table is the map of the message content-format to its underlying table
+// name, used by the Cassandra client to create the keyspace's schema.
+var Table = map[string]string{
+	"senml": senmlTable,
+	"json":  jsonTable,
+}
+
+// ErrInvalidMessage is returned when a consumed message isn't a supported
+// SenML or JSON message.
+var ErrInvalidMessage = errors.New("invalid message")
+
+var _ consumers.BlockingConsumer = (*cassandraRepository)(nil)
+
+type cassandraRepository struct {
+	session *gocql.Session
+}
+
+// New instantiates a Cassandra message repository that writes every
+// consumed message synchronously.
+func New(session *gocql.Session) consumers.BlockingConsumer {
+	return &cassandraRepository{session: session}
+}
+
+func (cr *cassandraRepository) Consume(ctx context.Context, message interface{}) error {
+	stmts, err := statementsFor(message)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range stmts {
+		if err := cr.session.Query(s.query, s.args...).Exec(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// statement is a single INSERT built from a consumed message, kept
+// query/args-only so it can be executed standalone or folded into a
+// gocql.Batch.
+type statement struct {
+	query string
+	args  []interface{}
+	size  int
+}
+
+// statementsFor builds one statement per row carried by message. It is
+// shared by the synchronous and the batching writer so both insert rows
+// identically.
+func statementsFor(message interface{}) ([]statement, error) {
+	switch m := message.(type) {
+	case json.Messages:
+		return senmlOrJSONStatements(nil, &m)
+	case []senml.Message:
+		return senmlOrJSONStatements(m, nil)
+	default:
+		return nil, ErrInvalidMessage
+	}
+}
+
+func senmlOrJSONStatements(senmlMsgs []senml.Message, jsonMsgs *json.Messages) ([]statement, error) {
+	if jsonMsgs != nil {
+		stmts := make([]statement, 0, len(jsonMsgs.Data))
+		for _, msg := range jsonMsgs.Data {
+			payload, err := json.EncodePayload(msg.Payload)
+			if err != nil {
+				return nil, err
+			}
+
+			args := []interface{}{msg.Channel, msg.Created, msg.Subtopic, msg.Publisher, msg.Protocol, payload}
+			stmts = append(stmts, statement{query: insertJSON, args: args, size: len(payload)})
+		}
+
+		return stmts, nil
+	}
+
+	stmts := make([]statement, 0, len(senmlMsgs))
+	for _, msg := range senmlMsgs {
+		args := []interface{}{
+			msg.Channel, msg.Subtopic, msg.Publisher, msg.Protocol, msg.Name, msg.Unit,
+			msg.Value, msg.StringValue, msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime,
+		}
+		stmts = append(stmts, statement{query: insertSenml, args: args, size: senmlRowSize(msg)})
+	}
+
+	return stmts, nil
+}
+
+// senmlRowSize is a rough estimate of a SenML row's on-wire size, used only
+// to bound the batching writer's buffer, not for billing or persistence.
+func senmlRowSize(msg senml.Message) int {
+	size := len(msg.Channel) + len(msg.Subtopic) + len(msg.Publisher) + len(msg.Protocol) + len(msg.Name) + len(msg.Unit)
+	if msg.StringValue != nil {
+		size += len(*msg.StringValue)
+	}
+	return size + 64
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/mg-contrib/opcua"
+)
+
+var _ opcua.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    opcua.Service
+}
+
+// LoggingMiddleware adds logging facilities to the OPC-UA adapter service.
+func LoggingMiddleware(svc opcua.Service, logger *slog.Logger) opcua.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateThing(ctx context.Context, mgxThing, opcuaNodeID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_thing for thing %s and node %s took %s to complete", mgxThing, opcuaNodeID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.CreateThing(ctx, mgxThing, opcuaNodeID)
+}
+
+func (lm *loggingMiddleware) CreateChannel(ctx context.Context, mgxChan, opcuaServerURI string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_channel for channel %s and server %s took %s to complete", mgxChan, opcuaServerURI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.CreateChannel(ctx, mgxChan, opcuaServerURI)
+}
+
+func (lm *loggingMiddleware) RemoveThing(ctx context.Context, mgxThing string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_thing for thing %s took %s to complete", mgxThing, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.RemoveThing(ctx, mgxThing)
+}
+
+func (lm *loggingMiddleware) RemoveChannel(ctx context.Context, mgxChan string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_channel for channel %s took %s to complete", mgxChan, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.RemoveChannel(ctx, mgxChan)
+}
+
+func (lm *loggingMiddleware) Connect(ctx context.Context, mgxConn string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method connect for connection %s took %s to complete", mgxConn, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.Connect(ctx, mgxConn)
+}
+
+func (lm *loggingMiddleware) Disconnect(ctx context.Context, mgxConn string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disconnect for connection %s took %s to complete", mgxConn, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.Disconnect(ctx, mgxConn)
+}
+
+func (lm *loggingMiddleware) Browse(ctx context.Context, cfg opcua.Config, namespace, identifier, identifierType string, opts opcua.BrowseOptions, sink func(opcua.BrowsedNode) error) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method browse for server %s took %s to complete", cfg.ServerURI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.Browse(ctx, cfg, namespace, identifier, identifierType, opts, sink)
+}
+
+func (lm *loggingMiddleware) Write(ctx context.Context, mgxChan, nodeID, dataType string, value interface{}) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method write for channel %s and node %s took %s to complete", mgxChan, nodeID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.Write(ctx, mgxChan, nodeID, dataType, value)
+}
+
+func (lm *loggingMiddleware) CallMethod(ctx context.Context, mgxChan, objectID, methodID string, inputArgs []interface{}) (out []interface{}, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method call_method for channel %s and method %s took %s to complete", mgxChan, methodID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+
+	return lm.svc.CallMethod(ctx, mgxChan, objectID, methodID, inputArgs)
+}
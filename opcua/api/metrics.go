@@ -0,0 +1,111 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/mg-contrib/opcua"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ opcua.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     opcua.Service
+}
+
+// MetricsMiddleware instruments the OPC-UA adapter service by tracking
+// request count and latency.
+func MetricsMiddleware(svc opcua.Service, counter metrics.Counter, latency metrics.Histogram) opcua.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateThing(ctx context.Context, mgxThing, opcuaNodeID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_thing").Add(1)
+		mm.latency.With("method", "create_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateThing(ctx, mgxThing, opcuaNodeID)
+}
+
+func (mm *metricsMiddleware) CreateChannel(ctx context.Context, mgxChan, opcuaServerURI string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_channel").Add(1)
+		mm.latency.With("method", "create_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateChannel(ctx, mgxChan, opcuaServerURI)
+}
+
+func (mm *metricsMiddleware) RemoveThing(ctx context.Context, mgxThing string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_thing").Add(1)
+		mm.latency.With("method", "remove_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveThing(ctx, mgxThing)
+}
+
+func (mm *metricsMiddleware) RemoveChannel(ctx context.Context, mgxChan string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_channel").Add(1)
+		mm.latency.With("method", "remove_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveChannel(ctx, mgxChan)
+}
+
+func (mm *metricsMiddleware) Connect(ctx context.Context, mgxConn string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "connect").Add(1)
+		mm.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Connect(ctx, mgxConn)
+}
+
+func (mm *metricsMiddleware) Disconnect(ctx context.Context, mgxConn string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disconnect").Add(1)
+		mm.latency.With("method", "disconnect").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Disconnect(ctx, mgxConn)
+}
+
+func (mm *metricsMiddleware) Browse(ctx context.Context, cfg opcua.Config, namespace, identifier, identifierType string, opts opcua.BrowseOptions, sink func(opcua.BrowsedNode) error) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "browse").Add(1)
+		mm.latency.With("method", "browse").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Browse(ctx, cfg, namespace, identifier, identifierType, opts, sink)
+}
+
+func (mm *metricsMiddleware) Write(ctx context.Context, mgxChan, nodeID, dataType string, value interface{}) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "write").Add(1)
+		mm.latency.With("method", "write").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Write(ctx, mgxChan, nodeID, dataType, value)
+}
+
+func (mm *metricsMiddleware) CallMethod(ctx context.Context, mgxChan, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "call_method").Add(1)
+		mm.latency.With("method", "call_method").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CallMethod(ctx, mgxChan, objectID, methodID, inputArgs)
+}
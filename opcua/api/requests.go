@@ -3,13 +3,36 @@
 
 package api
 
-import "github.com/absmach/magistrala/pkg/apiutil"
+import (
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/mg-contrib/opcua"
+)
 
 type browseReq struct {
 	ServerURI      string
 	Namespace      string
 	Identifier     string
 	IdentifierType string
+
+	// MaxDepth bounds how many levels the browse recurses below Identifier;
+	// IncludeAttributes additionally resolves DataType/AccessLevel/DisplayName
+	// for every visited node.
+	MaxDepth          int
+	IncludeAttributes bool
+
+	// SecurityPolicy, SecurityMode and the certificate/key pair configure the
+	// message security used to connect to ServerURI for the duration of the
+	// browse. AuthMode selects how the adapter authenticates to the server.
+	SecurityPolicy string
+	SecurityMode   string
+	CertFile       string
+	KeyFile        string
+
+	AuthMode     string
+	Username     string
+	Password     string
+	AuthCertFile string
+	AuthKeyFile  string
 }
 
 func (req *browseReq) validate() error {
@@ -17,5 +40,63 @@ func (req *browseReq) validate() error {
 		return apiutil.ErrMissingID
 	}
 
+	if req.IdentifierType == opcua.IdentifierTypeBrowsePath && req.Identifier == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.MaxDepth < 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if req.SecurityMode != "" && req.SecurityMode != opcua.SecurityModeNone {
+		if req.CertFile == "" || req.KeyFile == "" {
+			return opcua.ErrMissingCertFiles
+		}
+	}
+
+	switch req.AuthMode {
+	case "", opcua.AuthModeAnonymous:
+	case opcua.AuthModeUserName:
+		if req.Username == "" || req.Password == "" {
+			return apiutil.ErrMissingSecret
+		}
+	case opcua.AuthModeCert:
+		if req.AuthCertFile == "" || req.AuthKeyFile == "" {
+			return opcua.ErrMissingCertFiles
+		}
+	default:
+		return opcua.ErrInvalidAuthMode
+	}
+
+	return nil
+}
+
+type writeReq struct {
+	Channel  string      `json:"-"`
+	NodeID   string      `json:"node_id"`
+	DataType string      `json:"data_type"`
+	Value    interface{} `json:"value"`
+}
+
+func (req *writeReq) validate() error {
+	if req.Channel == "" || req.NodeID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type callMethodReq struct {
+	Channel   string        `json:"-"`
+	ObjectID  string        `json:"object_id"`
+	MethodID  string        `json:"method_id"`
+	InputArgs []interface{} `json:"input_args"`
+}
+
+func (req *callMethodReq) validate() error {
+	if req.Channel == "" || req.ObjectID == "" || req.MethodID == "" {
+		return apiutil.ErrMissingID
+	}
+
 	return nil
 }
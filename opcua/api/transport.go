@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/absmach/magistrala/pkg/apiutil"
+	"github.com/absmach/mg-contrib/opcua"
+	"github.com/go-chi/chi/v5"
+)
+
+// MakeHandler returns an HTTP handler for the OPC-UA adapter service.
+func MakeHandler(svc opcua.Service, logger *slog.Logger, instanceID string) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/nodes/browse", browseHandler(svc, logger))
+	r.Post("/nodes/write", writeHandler(svc, logger))
+	r.Post("/nodes/call", callMethodHandler(svc, logger))
+
+	return r
+}
+
+func writeHandler(svc opcua.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := writeReq{Channel: r.URL.Query().Get("channel")}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			encodeError(w, apiutil.ErrMalformedEntity)
+			return
+		}
+
+		if err := req.validate(); err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		if err := svc.Write(r.Context(), req.Channel, req.NodeID, req.DataType, req.Value); err != nil {
+			logger.Warn("write failed: " + err.Error())
+			encodeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func callMethodHandler(svc opcua.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := callMethodReq{Channel: r.URL.Query().Get("channel")}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			encodeError(w, apiutil.ErrMalformedEntity)
+			return
+		}
+
+		if err := req.validate(); err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		out, err := svc.CallMethod(r.Context(), req.Channel, req.ObjectID, req.MethodID, req.InputArgs)
+		if err != nil {
+			logger.Warn("call method failed: " + err.Error())
+			encodeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"output_args": out}); err != nil {
+			logger.Warn("failed to encode call method response: " + err.Error())
+		}
+	}
+}
+
+// browseHandler streams the browse tree back as a JSON array, flushing after
+// every top-level node, so that large industrial address spaces don't have
+// to be buffered in full before the first byte reaches the client.
+func browseHandler(svc opcua.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := browseReq{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			encodeError(w, apiutil.ErrMalformedEntity)
+			return
+		}
+
+		if err := req.validate(); err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		opts := opcua.BrowseOptions{MaxDepth: req.MaxDepth, IncludeAttributes: req.IncludeAttributes}
+		cfg := opcua.Config{
+			ServerURI:      req.ServerURI,
+			SecurityPolicy: req.SecurityPolicy,
+			SecurityMode:   req.SecurityMode,
+			CertFile:       req.CertFile,
+			KeyFile:        req.KeyFile,
+			AuthMode:       req.AuthMode,
+			Username:       req.Username,
+			Password:       req.Password,
+			AuthCertFile:   req.AuthCertFile,
+			AuthKeyFile:    req.AuthKeyFile,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+
+		flusher, canFlush := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+
+		// "[" is only written once the first node arrives, so a failure
+		// that happens before anything is streamed (e.g. the server
+		// connection itself fails) can still be reported as a proper error
+		// response instead of an empty array.
+		wrote := false
+		err := svc.Browse(r.Context(), cfg, req.Namespace, req.Identifier, req.IdentifierType, opts, func(n opcua.BrowsedNode) error {
+			if wrote {
+				w.Write([]byte(","))
+			} else {
+				w.Write([]byte("["))
+				wrote = true
+			}
+
+			if err := enc.Encode(n); err != nil {
+				return err
+			}
+			if canFlush {
+				flusher.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Warn("browse failed: " + err.Error())
+			if !wrote {
+				encodeError(w, err)
+				return
+			}
+		}
+
+		if wrote {
+			w.Write([]byte("]"))
+		} else {
+			w.Write([]byte("[]"))
+		}
+	}
+}
+
+func encodeError(w http.ResponseWriter, err error) {
+	switch err {
+	case apiutil.ErrMissingID, apiutil.ErrMalformedEntity:
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bolt provides a BoltDB-backed opcua.NodeRepository, for
+// single-node OPC-UA adapter deployments that don't need a separate
+// database.
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/absmach/mg-contrib/opcua"
+	bolt "go.etcd.io/bbolt"
+)
+
+var nodesBucket = []byte("opcua_nodes")
+
+var _ opcua.NodeRepository = (*nodeRepository)(nil)
+
+type nodeRepository struct {
+	db     *bolt.DB
+	secret string
+}
+
+// NewRepository returns a BoltDB-backed opcua.NodeRepository. It creates the
+// nodes bucket if it doesn't already exist. secret encrypts each stored
+// Node's Password at rest, so it's never written to the BoltDB file in the
+// clear; it must be the same value across restarts or stored passwords
+// become unrecoverable.
+func NewRepository(db *bolt.DB, secret string) (opcua.NodeRepository, error) {
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(nodesBucket)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &nodeRepository{db: db, secret: secret}, nil
+}
+
+func (nr *nodeRepository) Save(ctx context.Context, n opcua.Node) error {
+	password, err := opcua.EncryptSecret(nr.secret, n.Password)
+	if err != nil {
+		return err
+	}
+	n.Password = password
+
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	return nr.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(nodesBucket).Put(key(n.ServerURI, n.NodeID), data)
+	})
+}
+
+func (nr *nodeRepository) Remove(ctx context.Context, serverURI, nodeID string) error {
+	return nr.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(nodesBucket).Delete(key(serverURI, nodeID))
+	})
+}
+
+func (nr *nodeRepository) RetrieveAll(ctx context.Context, pm opcua.PageMetadata) (opcua.NodePage, error) {
+	var all []opcua.Node
+
+	if err := nr.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(nodesBucket).ForEach(func(_, v []byte) error {
+			var n opcua.Node
+			if err := json.Unmarshal(v, &n); err != nil {
+				return err
+			}
+
+			password, err := opcua.DecryptSecret(nr.secret, n.Password)
+			if err != nil {
+				return err
+			}
+			n.Password = password
+
+			all = append(all, n)
+			return nil
+		})
+	}); err != nil {
+		return opcua.NodePage{}, err
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].ServerURI != all[j].ServerURI {
+			return all[i].ServerURI < all[j].ServerURI
+		}
+		return all[i].NodeID < all[j].NodeID
+	})
+
+	total := uint64(len(all))
+
+	start := pm.Offset
+	if start > total {
+		start = total
+	}
+	end := start + pm.Limit
+	if pm.Limit == 0 || end > total {
+		end = total
+	}
+
+	return opcua.NodePage{
+		PageMetadata: pm,
+		Total:        total,
+		Nodes:        all[start:end],
+	}, nil
+}
+
+func key(serverURI, nodeID string) []byte {
+	return []byte(serverURI + "\x00" + nodeID)
+}
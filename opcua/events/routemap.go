@@ -0,0 +1,46 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains the Magistrala event-store integration for the
+// OPC-UA adapter: a Redis-backed route-map repository and a handler that
+// keeps it in sync with Thing/Channel/connection events.
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/absmach/mg-contrib/opcua"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "route_map"
+
+var _ opcua.RouteMapRepository = (*routeMapRepository)(nil)
+
+type routeMapRepository struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRouteMapRepository returns a Redis-backed RouteMapRepository, namespaced
+// under prefix (e.g. "thing", "channel", "connection").
+func NewRouteMapRepository(client *redis.Client, prefix string) opcua.RouteMapRepository {
+	return &routeMapRepository{client: client, prefix: prefix}
+}
+
+func (rr *routeMapRepository) Save(ctx context.Context, mgxID, opcuaID string) error {
+	return rr.client.Set(ctx, rr.key(mgxID), opcuaID, 0).Err()
+}
+
+func (rr *routeMapRepository) Get(ctx context.Context, mgxID string) (string, error) {
+	return rr.client.Get(ctx, rr.key(mgxID)).Result()
+}
+
+func (rr *routeMapRepository) Remove(ctx context.Context, mgxID string) error {
+	return rr.client.Del(ctx, rr.key(mgxID)).Err()
+}
+
+func (rr *routeMapRepository) key(mgxID string) string {
+	return fmt.Sprintf("%s:%s:%s", keyPrefix, rr.prefix, mgxID)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/mg-contrib/opcua"
+)
+
+const (
+	thingPrefix     = "thing."
+	channelPrefix   = "channel."
+	createSuffix    = "create"
+	removeSuffix    = "remove"
+	connectSuffix   = "connect"
+	disconnectEvent = "disconnect"
+)
+
+var _ events.Handler = (*eventHandler)(nil)
+
+type eventHandler struct {
+	svc opcua.Service
+}
+
+// NewEventHandler returns a handler that keeps the adapter's route-maps in
+// sync with Thing/Channel/connection events read from the event store.
+func NewEventHandler(svc opcua.Service) events.Handler {
+	return &eventHandler{svc: svc}
+}
+
+func (eh *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	operation, _ := msg["operation"].(string)
+
+	switch operation {
+	case thingPrefix + createSuffix:
+		return eh.svc.CreateThing(ctx, stringField(msg, "id"), stringField(msg, "opcua_node_id"))
+	case thingPrefix + removeSuffix:
+		return eh.svc.RemoveThing(ctx, stringField(msg, "id"))
+	case channelPrefix + createSuffix:
+		return eh.svc.CreateChannel(ctx, stringField(msg, "id"), stringField(msg, "opcua_server_uri"))
+	case channelPrefix + removeSuffix:
+		return eh.svc.RemoveChannel(ctx, stringField(msg, "id"))
+	case connectSuffix:
+		return eh.svc.Connect(ctx, stringField(msg, "channel_id")+":"+stringField(msg, "thing_id"))
+	case disconnectEvent:
+		return eh.svc.Disconnect(ctx, stringField(msg, "channel_id")+":"+stringField(msg, "thing_id"))
+	default:
+		return nil
+	}
+}
+
+func stringField(msg map[string]interface{}, key string) string {
+	s, _ := msg[key].(string)
+	return s
+}
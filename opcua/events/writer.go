@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/mg-contrib/opcua"
+)
+
+// writeMessage is the payload expected on the write-back channel: a value to
+// push to nodeID, encoded as dataType.
+type writeMessage struct {
+	NodeID   string      `json:"node_id"`
+	DataType string      `json:"data_type"`
+	Value    interface{} `json:"value"`
+}
+
+var _ messaging.MessageHandler = (*writeConsumer)(nil)
+
+type writeConsumer struct {
+	svc    opcua.Service
+	logger *slog.Logger
+}
+
+// NewWriteConsumer returns a messaging.MessageHandler that translates
+// messages published on a Magistrala channel into writes on the node named
+// in each message, on the OPC-UA server mapped to that channel, making the
+// adapter bidirectional.
+func NewWriteConsumer(svc opcua.Service, logger *slog.Logger) messaging.MessageHandler {
+	return &writeConsumer{svc: svc, logger: logger}
+}
+
+func (wc *writeConsumer) Handle(msg *messaging.Message) error {
+	var wm writeMessage
+	if err := json.Unmarshal(msg.GetPayload(), &wm); err != nil {
+		wc.logger.Warn("failed to decode write-back message: " + err.Error())
+		return err
+	}
+
+	if err := wc.svc.Write(context.Background(), msg.GetChannel(), wm.NodeID, wm.DataType, wm.Value); err != nil {
+		wc.logger.Warn("failed to write OPC-UA node " + wm.NodeID + ": " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (wc *writeConsumer) Cancel() error {
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/absmach/mg-contrib/opcua"
+	gopcua "github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+)
+
+var _ opcua.Browser = (*browser)(nil)
+
+type browser struct {
+	ctx    context.Context
+	logger *slog.Logger
+}
+
+// NewBrowser returns a new OPC-UA Browser.
+func NewBrowser(ctx context.Context, log *slog.Logger) opcua.Browser {
+	return &browser{ctx: ctx, logger: log}
+}
+
+func (b *browser) Browse(ctx context.Context, cfg opcua.Config, namespace, identifier, identifierType string, opts opcua.BrowseOptions, sink func(opcua.BrowsedNode) error) error {
+	serverURI := cfg.ServerURI
+
+	clOpts, err := clientOpts(cfg)
+	if err != nil {
+		return err
+	}
+
+	endpointOpts, err := gopcua.GetEndpointOptions(ctx, serverURI, clOpts...)
+	if err != nil {
+		return err
+	}
+
+	c, err := gopcua.NewClient(serverURI, endpointOpts...)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	root, err := resolveNode(ctx, c, namespace, identifier, identifierType)
+	if err != nil {
+		return err
+	}
+
+	return walkStream(ctx, root, opts.MaxDepth, opts.IncludeAttributes, sink)
+}
+
+// resolveNode addresses a node either by NodeID ("ns=<namespace>;<identifier>")
+// or, when identifierType is "browsepath", by translating a BrowsePath such
+// as "/Objects/Server/Status" relative to the Objects folder.
+func resolveNode(ctx context.Context, c *gopcua.Client, namespace, identifier, identifierType string) (*gopcua.Node, error) {
+	if identifierType != opcua.IdentifierTypeBrowsePath {
+		nodeID, err := ua.ParseNodeID(namespace + ";" + identifier)
+		if err != nil {
+			return nil, err
+		}
+		return c.Node(nodeID), nil
+	}
+
+	path := strings.Trim(identifier, "/")
+	browseNames := strings.Split(path, "/")
+
+	req := &ua.TranslateBrowsePathsToNodeIDsRequest{
+		BrowsePaths: []*ua.BrowsePath{
+			{
+				StartingNode: ua.NewNumericNodeID(0, id.ObjectsFolder),
+				RelativePath: relativePathFrom(browseNames),
+			},
+		},
+	}
+
+	resp, err := c.TranslateBrowsePathsToNodeIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) == 0 || len(resp.Results[0].Targets) == 0 {
+		return nil, opcua.ErrMalformedEntity
+	}
+
+	return c.Node(resp.Results[0].Targets[0].TargetID.NodeID), nil
+}
+
+func relativePathFrom(browseNames []string) *ua.RelativePath {
+	elements := make([]*ua.RelativePathElement, len(browseNames))
+	for i, name := range browseNames {
+		elements[i] = &ua.RelativePathElement{
+			ReferenceTypeID: ua.NewNumericNodeID(0, id.HierarchicalReferences),
+			IncludeSubtypes: true,
+			TargetName:      &ua.QualifiedName{Name: name},
+		}
+	}
+
+	return &ua.RelativePath{Elements: elements}
+}
+
+// walkStream browses node's direct children and, as soon as each child's own
+// subtree (up to maxDepth levels below it) has been resolved, passes it to
+// sink rather than accumulating the full set of children in memory. This
+// bounds the browse's memory use to a single subtree at a time instead of
+// the entire address space reachable from node; a child with a very large
+// or deep subtree of its own is still fully materialized by describe below
+// before it reaches sink, since it is handed over as one nested BrowsedNode.
+func walkStream(ctx context.Context, node *gopcua.Node, maxDepth int, includeAttributes bool, sink func(opcua.BrowsedNode) error) error {
+	refs, err := node.ReferencedNodes(ctx, id.HierarchicalReferences, ua.BrowseDirectionForward, ua.NodeClassAll, true)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range refs {
+		bn, err := describe(ctx, n, maxDepth, includeAttributes)
+		if err != nil {
+			return err
+		}
+		if err := sink(bn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// describe resolves n's own attributes and, if maxDepth allows, recurses
+// into its children via walk.
+func describe(ctx context.Context, n *gopcua.Node, maxDepth int, includeAttributes bool) (opcua.BrowsedNode, error) {
+	bn := opcua.BrowsedNode{NodeID: n.ID.String()}
+
+	if name, err := n.BrowseName(ctx); err == nil {
+		bn.BrowseName = name.Name
+	}
+
+	if includeAttributes {
+		if dn, err := n.DisplayName(ctx); err == nil && dn != nil {
+			bn.DisplayName = dn.Text
+		}
+		if desc, err := n.Description(ctx); err == nil && desc != nil {
+			bn.Description = desc.Text
+		}
+		if dt, err := n.Attribute(ctx, ua.AttributeIDDataType); err == nil && dt != nil && dt.Value != nil {
+			bn.DataType = dt.Value.String()
+		}
+		if al, err := n.Attribute(ctx, ua.AttributeIDAccessLevel); err == nil && al != nil && al.Value != nil {
+			bn.AccessLevel = al.Value.String()
+		}
+	}
+
+	if maxDepth > 0 {
+		children, err := walk(ctx, n, maxDepth-1, includeAttributes)
+		if err != nil {
+			return opcua.BrowsedNode{}, err
+		}
+		bn.Children = children
+	}
+
+	return bn, nil
+}
+
+// walk recursively collects a node's children, up to maxDepth levels.
+// maxDepth <= 0 returns only the direct children, matching the adapter's
+// previous, non-recursive behaviour.
+func walk(ctx context.Context, node *gopcua.Node, maxDepth int, includeAttributes bool) ([]opcua.BrowsedNode, error) {
+	refs, err := node.ReferencedNodes(ctx, id.HierarchicalReferences, ua.BrowseDirectionForward, ua.NodeClassAll, true)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]opcua.BrowsedNode, 0, len(refs))
+	for _, n := range refs {
+		bn, err := describe(ctx, n, maxDepth, includeAttributes)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, bn)
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gopcua contains the OPC-UA domain concept implementations based on
+// the gopcua/opcua client library.
+package gopcua
+
+import (
+	"fmt"
+
+	"github.com/absmach/mg-contrib/opcua"
+)
+
+// clientOpts translates a Config's security policy, security mode and
+// authentication settings into the option strings passed to
+// gopcua.GetEndpointOptions, whose result configures gopcua.NewClient. It is
+// shared by both the Subscriber and the Browser so
+// that the two always negotiate sessions the same way.
+func clientOpts(cfg opcua.Config) ([]string, error) {
+	opts := []string{
+		fmt.Sprintf("SecurityPolicy=%s", orDefault(cfg.SecurityPolicy, opcua.SecurityPolicyNone)),
+		fmt.Sprintf("SecurityMode=%s", orDefault(cfg.SecurityMode, opcua.SecurityModeNone)),
+	}
+
+	mode := orDefault(cfg.SecurityMode, opcua.SecurityModeNone)
+	if mode != opcua.SecurityModeNone {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, opcua.ErrMissingCertFiles
+		}
+		opts = append(opts, fmt.Sprintf("Certificate=%s", cfg.CertFile), fmt.Sprintf("PrivateKey=%s", cfg.KeyFile))
+	}
+
+	switch orDefault(cfg.AuthMode, opcua.AuthModeAnonymous) {
+	case opcua.AuthModeAnonymous:
+		opts = append(opts, "Auth-Anonymous")
+	case opcua.AuthModeUserName:
+		opts = append(opts, "Auth-Username", fmt.Sprintf("Username=%s", cfg.Username), fmt.Sprintf("Password=%s", cfg.Password))
+	case opcua.AuthModeCert:
+		if cfg.AuthCertFile == "" || cfg.AuthKeyFile == "" {
+			return nil, opcua.ErrMissingCertFiles
+		}
+		opts = append(opts, "Auth-Certificate", fmt.Sprintf("Certificate=%s", cfg.AuthCertFile), fmt.Sprintf("PrivateKey=%s", cfg.AuthKeyFile))
+	default:
+		return nil, opcua.ErrInvalidAuthMode
+	}
+
+	return opts, nil
+}
+
+func orDefault(val, def string) string {
+	if val == "" {
+		return def
+	}
+	return val
+}
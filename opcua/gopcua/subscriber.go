@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/mg-contrib/opcua"
+	gopcua "github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/monitor"
+)
+
+// protocol identifies messages this adapter publishes to the Magistrala
+// message broker.
+const protocol = "opcua"
+
+// defaultInterval is the monitored-item sampling interval used when cfg's
+// Interval doesn't parse as a positive number of milliseconds.
+const defaultInterval = time.Second
+
+var _ opcua.Subscriber = (*subscriber)(nil)
+
+type subscriber struct {
+	ctx     context.Context
+	pubsub  messaging.PubSub
+	thingRM opcua.RouteMapRepository
+	chanRM  opcua.RouteMapRepository
+	connRM  opcua.RouteMapRepository
+	nodes   opcua.NodeRepository
+	logger  *slog.Logger
+}
+
+// value is the payload published to the Magistrala broker for every value
+// change reported by the OPC-UA server.
+type value struct {
+	NodeID    string      `json:"node_id"`
+	Value     interface{} `json:"value"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// NewSubscriber returns a new OPC-UA Subscriber. Every subscription is
+// persisted to nodes once the connection to its server is established, so
+// that it can be re-established on adapter restart; the entry is written
+// before the monitored item is created and stays stored if that step fails.
+func NewSubscriber(ctx context.Context, pubsub messaging.PubSub, thingRM, chanRM, connRM opcua.RouteMapRepository, nodes opcua.NodeRepository, log *slog.Logger) opcua.Subscriber {
+	return &subscriber{
+		ctx:     ctx,
+		pubsub:  pubsub,
+		thingRM: thingRM,
+		chanRM:  chanRM,
+		connRM:  connRM,
+		nodes:   nodes,
+		logger:  log,
+	}
+}
+
+func (s *subscriber) Subscribe(ctx context.Context, mgxConn string, cfg opcua.Config) error {
+	opts, err := clientOpts(cfg)
+	if err != nil {
+		return err
+	}
+
+	endpointOpts, err := gopcua.GetEndpointOptions(ctx, cfg.ServerURI, opts...)
+	if err != nil {
+		return err
+	}
+
+	c, err := gopcua.NewClient(cfg.ServerURI, endpointOpts...)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	if err := s.nodes.Save(ctx, opcua.Node{
+		ServerURI:      cfg.ServerURI,
+		NodeID:         cfg.NodeID,
+		Connection:     mgxConn,
+		SecurityPolicy: cfg.SecurityPolicy,
+		SecurityMode:   cfg.SecurityMode,
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		AuthMode:       cfg.AuthMode,
+		Username:       cfg.Username,
+		Password:       cfg.Password,
+		AuthCertFile:   cfg.AuthCertFile,
+		AuthKeyFile:    cfg.AuthKeyFile,
+	}); err != nil {
+		s.logger.Warn("failed to persist subscription: " + err.Error())
+	}
+
+	channelID, thingID, _ := strings.Cut(mgxConn, ":")
+
+	m, err := monitor.NewNodeMonitor(c)
+	if err != nil {
+		return err
+	}
+
+	notifyCh := make(chan *monitor.DataChangeMessage, 16)
+	sub, err := m.ChanSubscribe(ctx, &gopcua.SubscriptionParameters{Interval: interval(cfg.Interval)}, notifyCh, cfg.NodeID)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe(ctx)
+
+	s.logger.Info("subscribed to OPC-UA server " + cfg.ServerURI + ", node " + cfg.NodeID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-notifyCh:
+			if msg.Error != nil {
+				s.logger.Warn("data change error for node " + cfg.NodeID + ": " + msg.Error.Error())
+				continue
+			}
+			if err := s.publish(ctx, channelID, thingID, cfg.NodeID, msg); err != nil {
+				s.logger.Warn("failed to publish value for node " + cfg.NodeID + ": " + err.Error())
+			}
+		}
+	}
+}
+
+// publish forwards a single OPC-UA value change to the Magistrala channel
+// identified by channelID, on behalf of thingID.
+func (s *subscriber) publish(ctx context.Context, channelID, thingID, nodeID string, msg *monitor.DataChangeMessage) error {
+	payload, err := json.Marshal(value{
+		NodeID:    nodeID,
+		Value:     msg.Value.Value(),
+		Timestamp: msg.SourceTimestamp,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.pubsub.Publish(ctx, channelID, &messaging.Message{
+		Channel:   channelID,
+		Publisher: thingID,
+		Protocol:  protocol,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	})
+}
+
+// interval parses ms (milliseconds, as configured by
+// Config.Interval/MG_OPCUA_ADAPTER_INTERVAL_MS) into the sampling interval
+// used for the monitored-item subscription, falling back to defaultInterval
+// if ms isn't a positive number.
+func interval(ms string) time.Duration {
+	n, err := strconv.Atoi(ms)
+	if err != nil || n <= 0 {
+		return defaultInterval
+	}
+
+	return time.Duration(n) * time.Millisecond
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/absmach/mg-contrib/opcua"
+	gopcua "github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+)
+
+var _ opcua.Writer = (*writer)(nil)
+
+type writer struct{}
+
+// NewWriter returns a new OPC-UA Writer.
+func NewWriter() opcua.Writer {
+	return &writer{}
+}
+
+func (w *writer) Write(ctx context.Context, cfg opcua.Config, nodeID, dataType string, value interface{}) error {
+	c, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	id, err := ua.ParseNodeID(nodeID)
+	if err != nil {
+		return err
+	}
+
+	typed, err := asDataType(dataType, value)
+	if err != nil {
+		return err
+	}
+
+	v, err := ua.NewVariant(typed)
+	if err != nil {
+		return err
+	}
+
+	req := &ua.WriteRequest{
+		NodesToWrite: []*ua.WriteValue{
+			{
+				NodeID:      id,
+				AttributeID: ua.AttributeIDValue,
+				Value:       &ua.DataValue{EncodingMask: ua.DataValueValue, Value: v},
+			},
+		},
+	}
+
+	resp, err := c.Write(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(resp.Results) > 0 && resp.Results[0] != ua.StatusOK {
+		return resp.Results[0]
+	}
+
+	return nil
+}
+
+func (w *writer) CallMethod(ctx context.Context, cfg opcua.Config, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error) {
+	c, err := connect(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close(ctx)
+
+	objID, err := ua.ParseNodeID(objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	mID, err := ua.ParseNodeID(methodID)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]*ua.Variant, len(inputArgs))
+	for i, a := range inputArgs {
+		v, err := ua.NewVariant(a)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	req := &ua.CallMethodRequest{
+		ObjectID:       objID,
+		MethodID:       mID,
+		InputArguments: args,
+	}
+
+	resp, err := c.Call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != ua.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	out := make([]interface{}, len(resp.OutputArguments))
+	for i, v := range resp.OutputArguments {
+		out[i] = v.Value()
+	}
+
+	return out, nil
+}
+
+// asDataType converts value, as decoded from JSON, into the Go type that
+// ua.NewVariant must receive to build the OPC-UA builtin type named by
+// dataType. encoding/json decodes every JSON number as float64, so without
+// this conversion a write to e.g. an Int32 node would build a Double
+// Variant and the server would reject it as a type mismatch. An empty
+// dataType leaves value as decoded.
+func asDataType(dataType string, value interface{}) (interface{}, error) {
+	switch dataType {
+	case "":
+		return value, nil
+	case "Boolean":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected bool for data type %s", opcua.ErrMalformedEntity, dataType)
+		}
+		return b, nil
+	case "String":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected string for data type %s", opcua.ErrMalformedEntity, dataType)
+		}
+		return s, nil
+	}
+
+	f, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected number for data type %s", opcua.ErrMalformedEntity, dataType)
+	}
+
+	switch dataType {
+	case "SByte":
+		return int8(f), nil
+	case "Byte":
+		return uint8(f), nil
+	case "Int16":
+		return int16(f), nil
+	case "UInt16":
+		return uint16(f), nil
+	case "Int32":
+		return int32(f), nil
+	case "UInt32":
+		return uint32(f), nil
+	case "Int64":
+		return int64(f), nil
+	case "UInt64":
+		return uint64(f), nil
+	case "Float":
+		return float32(f), nil
+	case "Double":
+		return f, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported data type %s", opcua.ErrMalformedEntity, dataType)
+	}
+}
+
+// connect opens an OPC-UA session against cfg.ServerURI using the same
+// security negotiation as the Subscriber and Browser.
+func connect(ctx context.Context, cfg opcua.Config) (*gopcua.Client, error) {
+	opts, err := clientOpts(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointOpts, err := gopcua.GetEndpointOptions(ctx, cfg.ServerURI, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := gopcua.NewClient(cfg.ServerURI, endpointOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
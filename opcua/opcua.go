@@ -0,0 +1,243 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opcua contains the domain concept definitions needed to support
+// Magistrala OPC-UA adapter service functionality.
+package opcua
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMalformedEntity indicates malformed entity specification.
+var ErrMalformedEntity = errors.New("malformed entity specification")
+
+// ErrMissingCertFiles indicates that a client certificate/key pair is
+// required for the requested security mode but was not provided.
+var ErrMissingCertFiles = errors.New("missing client certificate or key file")
+
+// ErrInvalidAuthMode indicates an unsupported authentication mode.
+var ErrInvalidAuthMode = errors.New("invalid authentication mode")
+
+// Security policies supported when establishing an OPC-UA session.
+const (
+	SecurityPolicyNone                = "None"
+	SecurityPolicyBasic128Rsa15       = "Basic128Rsa15"
+	SecurityPolicyBasic256            = "Basic256"
+	SecurityPolicyBasic256Sha256      = "Basic256Sha256"
+	SecurityPolicyAes128Sha256RsaOaep = "Aes128Sha256RsaOaep"
+	SecurityPolicyAes256Sha256RsaPss  = "Aes256Sha256RsaPss"
+)
+
+// Security modes supported when establishing an OPC-UA session.
+const (
+	SecurityModeNone           = "None"
+	SecurityModeSign           = "Sign"
+	SecurityModeSignAndEncrypt = "SignAndEncrypt"
+)
+
+// Authentication modes supported when establishing an OPC-UA session.
+const (
+	AuthModeAnonymous = "Anonymous"
+	AuthModeUserName  = "UserName"
+	AuthModeCert      = "Certificate"
+)
+
+// Identifier types accepted by Browse: a raw NodeID, or a BrowsePath such as
+// "/Objects/Server/Status" that is resolved to a NodeID on the server.
+const (
+	IdentifierTypeNodeID     = "nodeid"
+	IdentifierTypeBrowsePath = "browsepath"
+)
+
+// Config OPC-UA Server.
+type Config struct {
+	ServerURI string `env:"MG_OPCUA_ADAPTER_SERVER_URI"       envDefault:""`
+	NodeID    string `env:"MG_OPCUA_ADAPTER_NODE_ID"          envDefault:""`
+	Interval  string `env:"MG_OPCUA_ADAPTER_INTERVAL_MS"      envDefault:"1000"`
+
+	// SecurityPolicy and SecurityMode configure the message security used to
+	// establish the OPC-UA session, as defined by the OPC-UA specification.
+	SecurityPolicy string `env:"MG_OPCUA_ADAPTER_SECURITY_POLICY"  envDefault:"None"`
+	SecurityMode   string `env:"MG_OPCUA_ADAPTER_SECURITY_MODE"    envDefault:"None"`
+	CertFile       string `env:"MG_OPCUA_ADAPTER_CERT_FILE"        envDefault:""`
+	KeyFile        string `env:"MG_OPCUA_ADAPTER_KEY_FILE"         envDefault:""`
+
+	// AuthMode selects how the adapter authenticates to the server: as an
+	// anonymous user, with a username/password pair, or with an X.509 user
+	// token (AuthCertFile/AuthKeyFile).
+	AuthMode     string `env:"MG_OPCUA_ADAPTER_AUTH_MODE"        envDefault:"Anonymous"`
+	Username     string `env:"MG_OPCUA_ADAPTER_AUTH_USERNAME"    envDefault:""`
+	Password     string `env:"MG_OPCUA_ADAPTER_AUTH_PASSWORD"    envDefault:""`
+	AuthCertFile string `env:"MG_OPCUA_ADAPTER_AUTH_CERT_FILE"   envDefault:""`
+	AuthKeyFile  string `env:"MG_OPCUA_ADAPTER_AUTH_KEY_FILE"    envDefault:""`
+}
+
+// RouteMapRepository stores Thing/Channel connection as a map, where the key
+// is the Magistrala entity ID and value is the OPC-UA entity ID.
+type RouteMapRepository interface {
+	// Save stores/updates remote twin ID for local twin (channel/thing) ID.
+	Save(ctx context.Context, mgxID, opcuaID string) error
+
+	// Get returns remote twin ID for given local twin (channel/thing) ID.
+	Get(ctx context.Context, mgxID string) (string, error)
+
+	// Remove removes RouteMap between local and remote twin (channel/thing).
+	Remove(ctx context.Context, mgxID string) error
+}
+
+// Node represents a stored subscription: the server/node pair together with
+// the session settings needed to re-establish it unattended, on restart,
+// without falling back to the process-wide Config defaults.
+type Node struct {
+	ServerURI string
+	NodeID    string
+
+	// Connection is the Magistrala connection ("<channelID>:<thingID>")
+	// that owns this subscription, so a value received after a restart can
+	// still be published to the right channel on the right thing's behalf.
+	Connection string
+
+	SecurityPolicy string
+	SecurityMode   string
+	CertFile       string
+	KeyFile        string
+	AuthMode       string
+	Username       string
+	Password       string
+	AuthCertFile   string
+	AuthKeyFile    string
+}
+
+// PageMetadata contains the pagination parameters used by
+// NodeRepository.RetrieveAll.
+type PageMetadata struct {
+	Offset uint64
+	Limit  uint64
+}
+
+// NodePage is a paginated slice of stored Nodes.
+type NodePage struct {
+	PageMetadata
+	Total uint64
+	Nodes []Node
+}
+
+// NodeRepository persists the subscriptions the adapter has established, so
+// that they can be re-created on restart.
+type NodeRepository interface {
+	// Save stores n, replacing any previous entry for the same
+	// ServerURI/NodeID pair.
+	Save(ctx context.Context, n Node) error
+
+	// Remove deletes the stored subscription for the given ServerURI/NodeID
+	// pair, if any.
+	Remove(ctx context.Context, serverURI, nodeID string) error
+
+	// RetrieveAll returns a page of stored subscriptions, ordered by
+	// ServerURI then NodeID. A PageMetadata.Limit of 0 means unbounded: all
+	// subscriptions starting at Offset are returned.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (NodePage, error)
+}
+
+// Subscriber represents the OPC-UA entity that is able to subscribe to a
+// node on a remote OPC-UA server and forward the received values to the
+// Magistrala message broker.
+type Subscriber interface {
+	// Subscribe opens a monitored-item subscription to cfg.NodeID on
+	// cfg.ServerURI and forwards every value change to the Magistrala
+	// message broker, on behalf of the channel/thing connection identified
+	// by mgxConn ("<channelID>:<thingID>"), until ctx is done.
+	Subscribe(ctx context.Context, mgxConn string, cfg Config) error
+}
+
+// Browser represents the OPC-UA entity that is able to browse a remote
+// OPC-UA server's address space.
+type Browser interface {
+	// Browse walks the address space starting at the given node (addressed
+	// either by NodeID or, when identifierType is "browsepath", by a
+	// BrowsePath such as "/Objects/Server/Status"), recursing up to
+	// opts.MaxDepth levels. cfg.ServerURI selects the server and the
+	// remaining Config fields configure the security/auth used for the
+	// browse session. Rather than returning the whole tree at once, each
+	// direct child of the starting node is passed to sink as soon as it is
+	// discovered, bounding memory to one top-level subtree at a time instead
+	// of the entire address space; a child with a very large or deep subtree
+	// of its own is still fully resolved in memory before being handed to
+	// sink, since BrowsedNode.Children nests the whole subtree in one value.
+	// Browse stops and returns sink's error if sink returns one.
+	Browse(ctx context.Context, cfg Config, namespace, identifier, identifierType string, opts BrowseOptions, sink func(BrowsedNode) error) error
+}
+
+// BrowseOptions controls how deep a Browse call recurses into the address
+// space and whether per-node attributes are resolved along the way.
+type BrowseOptions struct {
+	// MaxDepth bounds recursion; 0 means direct children only.
+	MaxDepth int
+
+	// IncludeAttributes additionally resolves DataType, AccessLevel and
+	// DisplayName for every visited node, at the cost of extra round-trips.
+	IncludeAttributes bool
+}
+
+// Writer represents the OPC-UA entity that is able to push values and
+// invoke Methods on a remote OPC-UA server, making the adapter bidirectional
+// rather than read-only.
+type Writer interface {
+	// Write sets the value of nodeID, encoded as dataType (e.g. "Float",
+	// "Int32", "String"), on the server identified by cfg.
+	Write(ctx context.Context, cfg Config, nodeID, dataType string, value interface{}) error
+
+	// CallMethod invokes methodID on objectID with the given input
+	// arguments and returns the output arguments reported by the server.
+	CallMethod(ctx context.Context, cfg Config, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error)
+}
+
+// BrowsedNode represents a single node returned by a Browse call, along with
+// the children discovered below it, up to the requested depth.
+type BrowsedNode struct {
+	NodeID      string
+	BrowseName  string
+	DisplayName string
+	Description string
+	ClassName   string
+	DataType    string
+	AccessLevel string
+	Children    []BrowsedNode
+}
+
+// Service specifies an API for managing Thing/Channel <-> OPC-UA server/node
+// route-maps and for browsing and subscribing to OPC-UA servers.
+type Service interface {
+	// CreateThing creates thingID:nodeID route-map.
+	CreateThing(ctx context.Context, mgxThing, opcuaNodeID string) error
+
+	// CreateChannel creates channelID:serverURI route-map.
+	CreateChannel(ctx context.Context, mgxChan, opcuaServerURI string) error
+
+	// RemoveThing removes thingID:nodeID route-map.
+	RemoveThing(ctx context.Context, mgxThing string) error
+
+	// RemoveChannel removes channelID:serverURI route-map.
+	RemoveChannel(ctx context.Context, mgxChan string) error
+
+	// Connect connects thing and channel, and subscribes to the OPC-UA
+	// server/node pair that they are mapped to.
+	Connect(ctx context.Context, mgxConn string) error
+
+	// Disconnect disconnects thing and channel.
+	Disconnect(ctx context.Context, mgxConn string) error
+
+	// Browse walks the address-space tree rooted at the given OPC-UA node,
+	// passing each direct child to sink as soon as it is discovered. See
+	// Browser.Browse for details.
+	Browse(ctx context.Context, cfg Config, namespace, identifier, identifierType string, opts BrowseOptions, sink func(BrowsedNode) error) error
+
+	// Write pushes a value to nodeID on the OPC-UA server mapped to mgxChan.
+	Write(ctx context.Context, mgxChan, nodeID, dataType string, value interface{}) error
+
+	// CallMethod invokes methodID on objectID on the OPC-UA server mapped to
+	// mgxChan and returns the output arguments.
+	CallMethod(ctx context.Context, mgxChan, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error)
+}
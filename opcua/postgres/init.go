@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import migrate "github.com/rubenv/sql-migrate"
+
+// Migration returns the database's Postgres migrations for the OPC-UA
+// adapter's node table.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "opcua_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS nodes (
+						server_uri      VARCHAR(1024) NOT NULL,
+						node_id         VARCHAR(1024) NOT NULL,
+						security_policy VARCHAR(64)   NOT NULL DEFAULT '',
+						security_mode   VARCHAR(64)   NOT NULL DEFAULT '',
+						cert_file       VARCHAR(1024) NOT NULL DEFAULT '',
+						key_file        VARCHAR(1024) NOT NULL DEFAULT '',
+						auth_mode       VARCHAR(64)   NOT NULL DEFAULT '',
+						username        VARCHAR(256)  NOT NULL DEFAULT '',
+						password        VARCHAR(256)  NOT NULL DEFAULT '',
+						auth_cert_file  VARCHAR(1024) NOT NULL DEFAULT '',
+						auth_key_file   VARCHAR(1024) NOT NULL DEFAULT '',
+						PRIMARY KEY (server_uri, node_id)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS nodes",
+				},
+			},
+		},
+	}
+}
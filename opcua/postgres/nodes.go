@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package postgres provides a Postgres-backed opcua.NodeRepository,
+// suitable for multi-instance OPC-UA adapter deployments.
+package postgres
+
+import (
+	"context"
+
+	"github.com/absmach/mg-contrib/opcua"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ opcua.NodeRepository = (*nodeRepository)(nil)
+
+type nodeRepository struct {
+	db     *sqlx.DB
+	secret string
+}
+
+// NewRepository returns a Postgres-backed opcua.NodeRepository. secret
+// encrypts each stored Node's Password at rest, so it's never written to
+// the nodes table in the clear; it must be the same value across restarts
+// or stored passwords become unrecoverable.
+func NewRepository(db *sqlx.DB, secret string) opcua.NodeRepository {
+	return &nodeRepository{db: db, secret: secret}
+}
+
+func (nr *nodeRepository) Save(ctx context.Context, n opcua.Node) error {
+	password, err := opcua.EncryptSecret(nr.secret, n.Password)
+	if err != nil {
+		return err
+	}
+	n.Password = password
+
+	q := `INSERT INTO nodes (server_uri, node_id, security_policy, security_mode, cert_file, key_file, auth_mode, username, password, auth_cert_file, auth_key_file)
+		VALUES (:server_uri, :node_id, :security_policy, :security_mode, :cert_file, :key_file, :auth_mode, :username, :password, :auth_cert_file, :auth_key_file)
+		ON CONFLICT (server_uri, node_id) DO UPDATE SET
+			security_policy = EXCLUDED.security_policy,
+			security_mode   = EXCLUDED.security_mode,
+			cert_file       = EXCLUDED.cert_file,
+			key_file        = EXCLUDED.key_file,
+			auth_mode       = EXCLUDED.auth_mode,
+			username        = EXCLUDED.username,
+			password        = EXCLUDED.password,
+			auth_cert_file  = EXCLUDED.auth_cert_file,
+			auth_key_file   = EXCLUDED.auth_key_file`
+
+	_, err = nr.db.NamedExecContext(ctx, q, toDBNode(n))
+	return err
+}
+
+func (nr *nodeRepository) Remove(ctx context.Context, serverURI, nodeID string) error {
+	q := `DELETE FROM nodes WHERE server_uri = $1 AND node_id = $2`
+
+	_, err := nr.db.ExecContext(ctx, q, serverURI, nodeID)
+	return err
+}
+
+func (nr *nodeRepository) RetrieveAll(ctx context.Context, pm opcua.PageMetadata) (opcua.NodePage, error) {
+	q := `SELECT server_uri, node_id, security_policy, security_mode, cert_file, key_file, auth_mode, username, password, auth_cert_file, auth_key_file
+		FROM nodes ORDER BY server_uri, node_id OFFSET :offset`
+
+	params := map[string]interface{}{"offset": pm.Offset}
+	if pm.Limit > 0 {
+		q += ` LIMIT :limit`
+		params["limit"] = pm.Limit
+	}
+
+	rows, err := nr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return opcua.NodePage{}, err
+	}
+	defer rows.Close()
+
+	var nodes []opcua.Node
+	for rows.Next() {
+		var dbn dbNode
+		if err := rows.StructScan(&dbn); err != nil {
+			return opcua.NodePage{}, err
+		}
+
+		n := dbn.toNode()
+		password, err := opcua.DecryptSecret(nr.secret, n.Password)
+		if err != nil {
+			return opcua.NodePage{}, err
+		}
+		n.Password = password
+
+		nodes = append(nodes, n)
+	}
+
+	var total uint64
+	if err := nr.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM nodes`); err != nil {
+		return opcua.NodePage{}, err
+	}
+
+	return opcua.NodePage{
+		PageMetadata: pm,
+		Total:        total,
+		Nodes:        nodes,
+	}, nil
+}
+
+type dbNode struct {
+	ServerURI      string `db:"server_uri"`
+	NodeID         string `db:"node_id"`
+	SecurityPolicy string `db:"security_policy"`
+	SecurityMode   string `db:"security_mode"`
+	CertFile       string `db:"cert_file"`
+	KeyFile        string `db:"key_file"`
+	AuthMode       string `db:"auth_mode"`
+	Username       string `db:"username"`
+	Password       string `db:"password"`
+	AuthCertFile   string `db:"auth_cert_file"`
+	AuthKeyFile    string `db:"auth_key_file"`
+}
+
+func toDBNode(n opcua.Node) dbNode {
+	return dbNode{
+		ServerURI:      n.ServerURI,
+		NodeID:         n.NodeID,
+		SecurityPolicy: n.SecurityPolicy,
+		SecurityMode:   n.SecurityMode,
+		CertFile:       n.CertFile,
+		KeyFile:        n.KeyFile,
+		AuthMode:       n.AuthMode,
+		Username:       n.Username,
+		Password:       n.Password,
+		AuthCertFile:   n.AuthCertFile,
+		AuthKeyFile:    n.AuthKeyFile,
+	}
+}
+
+func (dbn dbNode) toNode() opcua.Node {
+	return opcua.Node{
+		ServerURI:      dbn.ServerURI,
+		NodeID:         dbn.NodeID,
+		SecurityPolicy: dbn.SecurityPolicy,
+		SecurityMode:   dbn.SecurityMode,
+		CertFile:       dbn.CertFile,
+		KeyFile:        dbn.KeyFile,
+		AuthMode:       dbn.AuthMode,
+		Username:       dbn.Username,
+		Password:       dbn.Password,
+		AuthCertFile:   dbn.AuthCertFile,
+		AuthKeyFile:    dbn.AuthKeyFile,
+	}
+}
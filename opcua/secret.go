@@ -0,0 +1,84 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// ErrDecryptSecret is returned when a stored secret can't be decrypted,
+// typically because it was encrypted under a different secret key.
+var ErrDecryptSecret = errors.New("failed to decrypt stored secret")
+
+// EncryptSecret encrypts plaintext with AES-GCM under a key derived from
+// secret, returning a value safe to persist alongside a stored subscription.
+// NodeRepository implementations use this to keep a subscription's password
+// out of BoltDB files and Postgres tables. An empty plaintext (no password
+// configured) is returned unchanged, so it stays empty in storage.
+func EncryptSecret(secret, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptSecret reverses EncryptSecret. An empty stored value decrypts to an
+// empty string without error.
+func DecryptSecret(secret, stored string) (string, error) {
+	if stored == "" {
+		return "", nil
+	}
+
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return "", ErrDecryptSecret
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", ErrDecryptSecret
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDecryptSecret
+	}
+
+	return string(plaintext), nil
+}
+
+// newGCM derives a 256-bit AES key from secret via SHA-256, so callers can
+// configure an arbitrary-length passphrase instead of an exact-size key.
+func newGCM(secret string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+const protocol = "opcua"
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	subscriber Subscriber
+	browser    Browser
+	writer     Writer
+	things     RouteMapRepository
+	channels   RouteMapRepository
+	connect    RouteMapRepository
+	nodes      NodeRepository
+	cfg        Config
+	logger     *slog.Logger
+}
+
+// New instantiates the OPC-UA adapter implementation.
+func New(sub Subscriber, browser Browser, writer Writer, things, channels, connect RouteMapRepository, nodes NodeRepository, cfg Config, logger *slog.Logger) Service {
+	return &adapterService{
+		subscriber: sub,
+		browser:    browser,
+		writer:     writer,
+		things:     things,
+		channels:   channels,
+		connect:    connect,
+		nodes:      nodes,
+		cfg:        cfg,
+		logger:     logger,
+	}
+}
+
+func (as *adapterService) CreateThing(ctx context.Context, mgxThing, opcuaNodeID string) error {
+	return as.things.Save(ctx, mgxThing, opcuaNodeID)
+}
+
+func (as *adapterService) CreateChannel(ctx context.Context, mgxChan, opcuaServerURI string) error {
+	return as.channels.Save(ctx, mgxChan, opcuaServerURI)
+}
+
+func (as *adapterService) RemoveThing(ctx context.Context, mgxThing string) error {
+	return as.things.Remove(ctx, mgxThing)
+}
+
+func (as *adapterService) RemoveChannel(ctx context.Context, mgxChan string) error {
+	return as.channels.Remove(ctx, mgxChan)
+}
+
+func (as *adapterService) Connect(ctx context.Context, mgxConn string) error {
+	cfg := as.cfg
+
+	channelID, thingID, _ := strings.Cut(mgxConn, ":")
+
+	nodeID, err := as.things.Get(ctx, thingID)
+	if err != nil {
+		return err
+	}
+	cfg.NodeID = nodeID
+
+	serverURI, err := as.channels.Get(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	cfg.ServerURI = serverURI
+
+	if err := as.connect.Save(ctx, mgxConn, mgxConn); err != nil {
+		return err
+	}
+
+	go func() {
+		if err := as.subscriber.Subscribe(ctx, mgxConn, cfg); err != nil {
+			as.logger.Warn("subscription failed: " + err.Error())
+		}
+	}()
+
+	return nil
+}
+
+func (as *adapterService) Disconnect(ctx context.Context, mgxConn string) error {
+	channelID, thingID, _ := strings.Cut(mgxConn, ":")
+
+	nodeID, err := as.things.Get(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	serverURI, err := as.channels.Get(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	if err := as.nodes.Remove(ctx, serverURI, nodeID); err != nil {
+		as.logger.Warn("failed to remove stored subscription: " + err.Error())
+	}
+
+	return as.connect.Remove(ctx, mgxConn)
+}
+
+func (as *adapterService) Browse(ctx context.Context, cfg Config, namespace, identifier, identifierType string, opts BrowseOptions, sink func(BrowsedNode) error) error {
+	return as.browser.Browse(ctx, cfg, namespace, identifier, identifierType, opts, sink)
+}
+
+func (as *adapterService) Write(ctx context.Context, mgxChan, nodeID, dataType string, value interface{}) error {
+	cfg := as.cfg
+
+	serverURI, err := as.channels.Get(ctx, mgxChan)
+	if err != nil {
+		return err
+	}
+	cfg.ServerURI = serverURI
+
+	return as.writer.Write(ctx, cfg, nodeID, dataType, value)
+}
+
+func (as *adapterService) CallMethod(ctx context.Context, mgxChan, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error) {
+	cfg := as.cfg
+
+	serverURI, err := as.channels.Get(ctx, mgxChan)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ServerURI = serverURI
+
+	return as.writer.CallMethod(ctx, cfg, objectID, methodID, inputArgs)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/mg-contrib/opcua"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sony/gobreaker"
+)
+
+var breakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "breaker_state",
+	Help: "Circuit breaker state (0=closed, 1=half-open, 2=open), by breaker name.",
+}, []string{"name"})
+
+func init() {
+	prometheus.MustRegister(breakerState)
+}
+
+func newBreaker(st Settings) *gobreaker.CircuitBreaker {
+	gst := st.toGobreaker()
+
+	onChange := gst.OnStateChange
+	gst.OnStateChange = func(name string, from, to gobreaker.State) {
+		breakerState.With(prometheus.Labels{"name": name}).Set(float64(to))
+		if onChange != nil {
+			onChange(name, from, to)
+		}
+	}
+
+	return gobreaker.NewCircuitBreaker(gst)
+}
+
+var _ consumers.BlockingConsumer = (*circuitBreakerConsumer)(nil)
+
+type circuitBreakerConsumer struct {
+	next    consumers.BlockingConsumer
+	breaker *gobreaker.CircuitBreaker
+}
+
+// NewCircuitBreaker wraps next with a circuit breaker: once
+// st.ConsecutiveFailures consecutive calls fail, Consume short-circuits with
+// ErrCircuitOpen for st.Timeout before probing next again.
+func NewCircuitBreaker(next consumers.BlockingConsumer, st Settings) consumers.BlockingConsumer {
+	return &circuitBreakerConsumer{next: next, breaker: newBreaker(st)}
+}
+
+func (cb *circuitBreakerConsumer) Consume(ctx context.Context, message interface{}) error {
+	_, err := cb.breaker.Execute(func() (interface{}, error) {
+		return nil, cb.next.Consume(ctx, message)
+	})
+	if err == gobreaker.ErrOpenState || err == gobreaker.ErrTooManyRequests {
+		return ErrCircuitOpen
+	}
+
+	return err
+}
+
+var _ opcua.Service = (*circuitBreakerService)(nil)
+
+type circuitBreakerService struct {
+	next    opcua.Service
+	breaker *gobreaker.CircuitBreaker
+}
+
+// NewOPCUACircuitBreaker wraps an opcua.Service with the same breaker
+// semantics as NewCircuitBreaker.
+func NewOPCUACircuitBreaker(next opcua.Service, st Settings) opcua.Service {
+	return &circuitBreakerService{next: next, breaker: newBreaker(st)}
+}
+
+func (cb *circuitBreakerService) guard(fn func() error) error {
+	_, err := cb.breaker.Execute(func() (interface{}, error) {
+		return nil, fn()
+	})
+	if err == gobreaker.ErrOpenState || err == gobreaker.ErrTooManyRequests {
+		return ErrCircuitOpen
+	}
+
+	return err
+}
+
+func (cb *circuitBreakerService) CreateThing(ctx context.Context, mgxThing, opcuaNodeID string) error {
+	return cb.guard(func() error { return cb.next.CreateThing(ctx, mgxThing, opcuaNodeID) })
+}
+
+func (cb *circuitBreakerService) CreateChannel(ctx context.Context, mgxChan, opcuaServerURI string) error {
+	return cb.guard(func() error { return cb.next.CreateChannel(ctx, mgxChan, opcuaServerURI) })
+}
+
+func (cb *circuitBreakerService) RemoveThing(ctx context.Context, mgxThing string) error {
+	return cb.guard(func() error { return cb.next.RemoveThing(ctx, mgxThing) })
+}
+
+func (cb *circuitBreakerService) RemoveChannel(ctx context.Context, mgxChan string) error {
+	return cb.guard(func() error { return cb.next.RemoveChannel(ctx, mgxChan) })
+}
+
+func (cb *circuitBreakerService) Connect(ctx context.Context, mgxConn string) error {
+	return cb.guard(func() error { return cb.next.Connect(ctx, mgxConn) })
+}
+
+func (cb *circuitBreakerService) Disconnect(ctx context.Context, mgxConn string) error {
+	return cb.guard(func() error { return cb.next.Disconnect(ctx, mgxConn) })
+}
+
+func (cb *circuitBreakerService) Browse(ctx context.Context, cfg opcua.Config, namespace, identifier, identifierType string, opts opcua.BrowseOptions, sink func(opcua.BrowsedNode) error) error {
+	return cb.guard(func() error {
+		return cb.next.Browse(ctx, cfg, namespace, identifier, identifierType, opts, sink)
+	})
+}
+
+func (cb *circuitBreakerService) Write(ctx context.Context, mgxChan, nodeID, dataType string, value interface{}) error {
+	return cb.guard(func() error { return cb.next.Write(ctx, mgxChan, nodeID, dataType, value) })
+}
+
+func (cb *circuitBreakerService) CallMethod(ctx context.Context, mgxChan, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error) {
+	var out []interface{}
+	err := cb.guard(func() error {
+		var err error
+		out, err = cb.next.CallMethod(ctx, mgxChan, objectID, methodID, inputArgs)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package middleware provides circuit-breaker and rate-limiter decorators
+// that can be plugged into a consumer or OPC-UA service chain, protecting
+// downstream Cassandra/OPC-UA servers from cascading failures.
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+// ErrCircuitOpen is returned instead of calling through to next when the
+// circuit breaker is open. Callers in the broker consumer path should treat
+// this as a transient failure and NACK/retry the message rather than
+// dropping it.
+var ErrCircuitOpen = errors.New("circuit breaker open: downstream unavailable")
+
+// ErrRateLimited is returned when a call is rejected because it would
+// exceed the configured rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// Settings configures a circuit breaker decorator.
+type Settings struct {
+	// Name identifies the breaker in logs and metrics.
+	Name string
+
+	// MaxRequests is the maximum number of requests allowed to pass through
+	// while the breaker is half-open.
+	MaxRequests uint32
+
+	// Interval is the cyclic period, while the breaker is closed, after
+	// which its failure counts are reset. Zero disables the reset.
+	Interval time.Duration
+
+	// Timeout is how long the breaker stays open before moving to
+	// half-open.
+	Timeout time.Duration
+
+	// ConsecutiveFailures opens the breaker once this many consecutive
+	// requests have failed.
+	ConsecutiveFailures uint32
+}
+
+func (s Settings) toGobreaker() gobreaker.Settings {
+	st := gobreaker.Settings{
+		Name:        s.Name,
+		MaxRequests: s.MaxRequests,
+		Interval:    s.Interval,
+		Timeout:     s.Timeout,
+	}
+
+	threshold := s.ConsecutiveFailures
+	if threshold == 0 {
+		threshold = 5
+	}
+	st.ReadyToTrip = func(counts gobreaker.Counts) bool {
+		return counts.ConsecutiveFailures >= threshold
+	}
+
+	return st
+}
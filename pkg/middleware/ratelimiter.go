@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/mg-contrib/opcua"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+var rateLimited = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "rate_limited_total",
+	Help: "Number of calls rejected because they exceeded the configured rate limit.",
+})
+
+func init() {
+	prometheus.MustRegister(rateLimited)
+}
+
+var _ consumers.BlockingConsumer = (*rateLimiterConsumer)(nil)
+
+type rateLimiterConsumer struct {
+	next    consumers.BlockingConsumer
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter wraps next so that Consume is rejected with ErrRateLimited
+// once more than rps requests per second, with a burst of burst, are
+// attempted.
+func NewRateLimiter(next consumers.BlockingConsumer, rps, burst int) consumers.BlockingConsumer {
+	return &rateLimiterConsumer{next: next, limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+}
+
+func (rl *rateLimiterConsumer) Consume(ctx context.Context, message interface{}) error {
+	if !rl.limiter.Allow() {
+		rateLimited.Inc()
+		return ErrRateLimited
+	}
+
+	return rl.next.Consume(ctx, message)
+}
+
+var _ opcua.Service = (*rateLimiterService)(nil)
+
+type rateLimiterService struct {
+	next    opcua.Service
+	limiter *rate.Limiter
+}
+
+// NewOPCUARateLimiter wraps an opcua.Service with the same rate-limiting
+// semantics as NewRateLimiter.
+func NewOPCUARateLimiter(next opcua.Service, rps, burst int) opcua.Service {
+	return &rateLimiterService{next: next, limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+}
+
+func (rl *rateLimiterService) allow() error {
+	if !rl.limiter.Allow() {
+		rateLimited.Inc()
+		return ErrRateLimited
+	}
+	return nil
+}
+
+func (rl *rateLimiterService) CreateThing(ctx context.Context, mgxThing, opcuaNodeID string) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.CreateThing(ctx, mgxThing, opcuaNodeID)
+}
+
+func (rl *rateLimiterService) CreateChannel(ctx context.Context, mgxChan, opcuaServerURI string) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.CreateChannel(ctx, mgxChan, opcuaServerURI)
+}
+
+func (rl *rateLimiterService) RemoveThing(ctx context.Context, mgxThing string) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.RemoveThing(ctx, mgxThing)
+}
+
+func (rl *rateLimiterService) RemoveChannel(ctx context.Context, mgxChan string) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.RemoveChannel(ctx, mgxChan)
+}
+
+func (rl *rateLimiterService) Connect(ctx context.Context, mgxConn string) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.Connect(ctx, mgxConn)
+}
+
+func (rl *rateLimiterService) Disconnect(ctx context.Context, mgxConn string) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.Disconnect(ctx, mgxConn)
+}
+
+func (rl *rateLimiterService) Browse(ctx context.Context, cfg opcua.Config, namespace, identifier, identifierType string, opts opcua.BrowseOptions, sink func(opcua.BrowsedNode) error) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.Browse(ctx, cfg, namespace, identifier, identifierType, opts, sink)
+}
+
+func (rl *rateLimiterService) Write(ctx context.Context, mgxChan, nodeID, dataType string, value interface{}) error {
+	if err := rl.allow(); err != nil {
+		return err
+	}
+	return rl.next.Write(ctx, mgxChan, nodeID, dataType, value)
+}
+
+func (rl *rateLimiterService) CallMethod(ctx context.Context, mgxChan, objectID, methodID string, inputArgs []interface{}) ([]interface{}, error) {
+	if err := rl.allow(); err != nil {
+		return nil, err
+	}
+	return rl.next.CallMethod(ctx, mgxChan, objectID, methodID, inputArgs)
+}